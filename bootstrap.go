@@ -0,0 +1,138 @@
+package routinghelpers
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"time"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+// BootstrapConfig controls a periodic bootstrap loop started via BootstrapOnSignal.
+type BootstrapConfig struct {
+	// Period is informational only; the actual cadence is driven by the signal channel passed
+	// to BootstrapOnSignal. It is kept here so callers constructing the backing ticker have a
+	// single place to read the intended period from.
+	Period time.Duration
+	// Timeout bounds all Bootstrap/FindPeer calls issued during a single tick together: they share one
+	// deadline, so time spent in Bootstrap is not available to the random-walk lookups that follow.
+	Timeout time.Duration
+	// Queries is the amount of random-walk FindPeer lookups issued on each tick to warm routing tables.
+	Queries int
+}
+
+// BootstrapOnSignal runs a periodic bootstrap loop in its own goroutine: on every tick received from
+// signal, it calls Bootstrap on every child Router (respecting their ExecuteAfter/Timeout/IgnoreError)
+// and issues cfg.Queries random-walk FindPeer lookups to warm routing tables. Call Close on the returned
+// io.Closer to stop the loop; closing signal has the same effect.
+func (r *ComposableParallel) BootstrapOnSignal(cfg BootstrapConfig, signal <-chan time.Time) io.Closer {
+	return startBootstrapLoop(cfg, signal, r.Bootstrap, r.randomWalk)
+}
+
+// BootstrapOnSignal runs a periodic bootstrap loop in its own goroutine: on every tick received from
+// signal, it calls Bootstrap on every child Router, in order, and issues cfg.Queries random-walk FindPeer
+// lookups to warm routing tables. Call Close on the returned io.Closer to stop the loop; closing signal
+// has the same effect.
+func (r *ComposableSequential) BootstrapOnSignal(cfg BootstrapConfig, signal <-chan time.Time) io.Closer {
+	return startBootstrapLoop(cfg, signal, r.Bootstrap, r.randomWalk)
+}
+
+// randomWalk issues queries random-walk FindPeer lookups against the composed Routers to warm
+// their routing tables.
+func (r *ComposableParallel) randomWalk(ctx context.Context, queries int) {
+	randomWalk(ctx, queries, r.FindPeer)
+}
+
+// randomWalk issues queries random-walk FindPeer lookups against the composed Routers to warm
+// their routing tables.
+func (r *ComposableSequential) randomWalk(ctx context.Context, queries int) {
+	randomWalk(ctx, queries, r.FindPeer)
+}
+
+func randomWalk(ctx context.Context, queries int, findPeer func(context.Context, peer.ID) (peer.AddrInfo, error)) {
+	for i := 0; i < queries; i++ {
+		if ctx.Err() != nil {
+			return
+		}
+		// A random peer.ID is enough to drive a DHT-style random walk: the lookup traverses
+		// the table towards a peer that doesn't exist, discovering and caching peers along the way.
+		_, _ = findPeer(ctx, randomPeerID())
+	}
+}
+
+func randomPeerID() peer.ID {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return peer.ID(b)
+}
+
+type bootstrapCloser struct {
+	cancel context.CancelFunc
+}
+
+func (b *bootstrapCloser) Close() error {
+	b.cancel()
+	return nil
+}
+
+func startBootstrapLoop(
+	cfg BootstrapConfig,
+	signal <-chan time.Time,
+	bootstrap func(context.Context) error,
+	randomWalk func(context.Context, int),
+) io.Closer {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-signal:
+				if !ok {
+					return
+				}
+
+				tctx, tcancel := context.WithTimeout(ctx, cfg.Timeout)
+				_ = bootstrap(tctx)
+				randomWalk(tctx, cfg.Queries)
+				tcancel()
+			}
+		}
+	}()
+
+	return &bootstrapCloser{cancel: cancel}
+}
+
+// AmplifySignal fans a single ticker-like channel out to n independent channels. A single producer
+// goroutine reads from src and, on every tick, writes to all n outputs. Each output holds at most one
+// pending tick: a slow consumer that has not yet taken the previous tick has the new one dropped rather
+// than blocking the producer or the other consumers. All outputs are closed once src is closed.
+func AmplifySignal(src <-chan time.Time, n int) []<-chan time.Time {
+	outs := make([]chan time.Time, n)
+	result := make([]<-chan time.Time, n)
+	for i := range outs {
+		outs[i] = make(chan time.Time, 1)
+		result[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+
+		for t := range src {
+			for _, out := range outs {
+				select {
+				case out <- t:
+				default:
+				}
+			}
+		}
+	}()
+
+	return result
+}
@@ -0,0 +1,110 @@
+package routinghelpers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mockBootstrapRouter embeds mockProvidersRouter and counts Bootstrap calls.
+type mockBootstrapRouter struct {
+	mockProvidersRouter
+	bootstraps int32
+}
+
+func (m *mockBootstrapRouter) Bootstrap(ctx context.Context) error {
+	atomic.AddInt32(&m.bootstraps, 1)
+	return nil
+}
+
+func TestComposableParallelBootstrapOnSignal(t *testing.T) {
+	br := &mockBootstrapRouter{}
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: br, Timeout: time.Second},
+	})
+
+	signal := make(chan time.Time)
+	closer := router.BootstrapOnSignal(BootstrapConfig{Timeout: time.Second}, signal)
+	defer closer.Close()
+
+	signal <- time.Now()
+	signal <- time.Now()
+
+	deadline := time.After(2 * time.Second)
+	for {
+		if atomic.LoadInt32(&br.bootstraps) >= 2 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected at least 2 Bootstrap calls, got %d", br.bootstraps)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	if err := closer.Close(); err != nil {
+		t.Fatalf("expected Close to succeed, got %v", err)
+	}
+}
+
+func TestAmplifySignalFansOutAndClosesOnSourceClose(t *testing.T) {
+	src := make(chan time.Time)
+	outs := AmplifySignal(src, 3)
+
+	tick := time.Now()
+	src <- tick
+
+	for i, out := range outs {
+		select {
+		case got, ok := <-out:
+			if !ok {
+				t.Fatalf("output %d closed before any tick was consumed", i)
+			}
+			if !got.Equal(tick) {
+				t.Fatalf("output %d got wrong tick: %v", i, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("output %d did not receive the tick in time", i)
+		}
+	}
+
+	close(src)
+
+	for i, out := range outs {
+		select {
+		case _, ok := <-out:
+			if ok {
+				t.Fatalf("output %d should be closed once src is closed", i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("output %d was not closed in time", i)
+		}
+	}
+}
+
+func TestAmplifySignalDropsTicksForSlowConsumers(t *testing.T) {
+	src := make(chan time.Time)
+	outs := AmplifySignal(src, 1)
+	defer close(src)
+
+	// Send a tick that nobody reads; the producer must not block on it.
+	done := make(chan struct{})
+	go func() {
+		src <- time.Now()
+		src <- time.Now()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("AmplifySignal producer blocked on a slow consumer instead of dropping the tick")
+	}
+
+	// Draining afterwards should still work for subsequent ticks.
+	select {
+	case <-outs[0]:
+	case <-time.After(time.Second):
+	}
+}
@@ -13,12 +13,18 @@ type ParallelRouter struct {
 	IgnoreError  bool
 	Router       routing.Routing
 	ExecuteAfter time.Duration
+	// Label identifies this Router in metrics and traces emitted by the composing Router. Optional;
+	// defaults to an empty string if unset.
+	Label string
 }
 
 type SequentialRouter struct {
 	Timeout     time.Duration
 	IgnoreError bool
 	Router      routing.Routing
+	// Label identifies this Router in metrics and traces emitted by the composing Router. Optional;
+	// defaults to an empty string if unset.
+	Label string
 }
 
 type ProvideManyRouter interface {
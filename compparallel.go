@@ -4,19 +4,25 @@ import (
 	"context"
 	"errors"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/ipfs/go-cid"
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/libp2p/go-libp2p-core/routing"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/multiformats/go-multihash"
 )
 
+// provideManyWorkers is the amount of concurrent Provide calls used to fan out a ProvideMany
+// to a Router that does not implement ProvideManyRouter itself.
+const provideManyWorkers = 10
+
 var _ routing.Routing = &Parallel{}
 
 type ComposableParallel struct {
 	routers []*ParallelRouter
+	ins     instrumentation
 }
 
 // NewComposableParallel creates a Router that will execute methods from provided Routers in parallel.
@@ -26,38 +32,152 @@ type ComposableParallel struct {
 func NewComposableParallel(routers []*ParallelRouter) *ComposableParallel {
 	return &ComposableParallel{
 		routers: routers,
+		ins:     newInstrumentation(nil, nil),
 	}
 }
 
+// WithTracer sets the Tracer used to emit spans for every composed call. Passing nil restores the
+// no-op default.
+func (r *ComposableParallel) WithTracer(tracer Tracer) *ComposableParallel {
+	r.ins = newInstrumentation(tracer, r.ins.reporter)
+	return r
+}
+
+// WithMetricsReporter sets the MetricsReporter used to emit latency/result/contribution metrics for
+// every composed call. Passing nil restores the no-op default.
+func (r *ComposableParallel) WithMetricsReporter(reporter MetricsReporter) *ComposableParallel {
+	r.ins = newInstrumentation(r.ins.tracer, reporter)
+	return r
+}
+
 // Provide will call all Routers in parallel.
 func (r *ComposableParallel) Provide(ctx context.Context, cid cid.Cid, provide bool) error {
-	return execute(ctx, r.routers,
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableParallel.Provide")
+	defer end()
+	return execute(ctx, "Provide", r.ins, r.routers,
 		func(ctx context.Context, r routing.Routing) error {
 			return r.Provide(ctx, cid, provide)
 		},
 	)
 }
 
-// FindProvidersAsync will execute all Routers in parallel, iterating results from them in unspecified oredr.
-// If count is set, only that amount of elements will be returned without any specification about from what router is obtained.
+// ProvideMany will call ProvideMany on all Routers that implement ProvideManyRouter and are Ready, in
+// parallel. Routers that don't implement ProvideManyRouter (or aren't Ready) will instead have Provide
+// called once per key, fanned out across a bounded worker pool. ExecuteAfter, Timeout and IgnoreError
+// are honored per Router, same as execute().
+func (r *ComposableParallel) ProvideMany(ctx context.Context, keys []multihash.Multihash) error {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableParallel.ProvideMany")
+	defer end()
+	return execute(ctx, "ProvideMany", r.ins, r.routers,
+		func(ctx context.Context, router routing.Routing) error {
+			if pm, ok := router.(ProvideManyRouter); ok && pm.Ready() {
+				return pm.ProvideMany(ctx, keys)
+			}
+
+			return provideManyFallback(ctx, router, keys)
+		},
+	)
+}
+
+// Ready will report true only if every child Router implementing ProvideManyRouter is Ready. Routers
+// that don't implement ProvideManyRouter are not taken into account, as they don't need to warm up.
+func (r *ComposableParallel) Ready() bool {
+	for _, router := range r.routers {
+		pm, ok := router.Router.(ProvideManyRouter)
+		if !ok {
+			continue
+		}
+
+		if !pm.Ready() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// provideManyFallback calls Provide once per key on router, using a bounded worker pool, for Routers
+// that don't implement ProvideManyRouter themselves.
+func provideManyFallback(ctx context.Context, router routing.Routing, keys []multihash.Multihash) error {
+	keysChan := make(chan multihash.Multihash)
+	go func() {
+		defer close(keysChan)
+		for _, k := range keys {
+			select {
+			case keysChan <- k:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, provideManyWorkers)
+	for i := 0; i < provideManyWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range keysChan {
+				c := cid.NewCidV1(cid.Raw, k)
+				if err := router.Provide(ctx, c, true); err != nil {
+					errCh <- err
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(errCh)
+	}()
+
+	var errOut error
+	for err := range errCh {
+		errOut = multierror.Append(errOut, err)
+	}
+
+	return errOut
+}
+
+// FindProvidersAsync will execute all Routers in parallel, iterating results from them in unspecified order.
+// Results are deduplicated by peer.ID across all Routers: if several Routers return the same peer, their
+// Addrs are merged and an updated peer.AddrInfo is emitted only when the merge actually added new addrs.
+// If count is set, no more than that amount of unique peers will be emitted; re-emits carrying merged addrs
+// for an already-counted peer don't consume a new slot.
 // To gather providers from a set of Routers first, you can use the ExecuteAfter timer to delay some Router execution.
 func (r *ComposableParallel) FindProvidersAsync(ctx context.Context, cid cid.Cid, count int) <-chan peer.AddrInfo {
+	const method = "FindProvidersAsync"
+	ins := r.ins
+	ctx, endSpan := ins.tracer.StartSpan(ctx, "ComposableParallel."+method)
+
 	addrChanOut := make(chan peer.AddrInfo)
-	var totalCount int64
+
+	dedup := newDedupedProviders(count)
+
 	var wg sync.WaitGroup
 	for _, r := range r.routers {
 		r := r
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			contributed := 0
+			defer func() { ins.observeContribution(method, r.Label, contributed) }()
+
+			waitStart := time.Now()
 			tim := time.NewTimer(r.ExecuteAfter)
 			defer tim.Stop()
 			select {
 			case <-ctx.Done():
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), false)
 				return
 			case <-tim.C:
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), true)
 				ctx, cancel := context.WithTimeout(ctx, r.Timeout)
 				defer cancel()
+				ctx, endCall := ins.startCall(ctx, method, r.Label)
+				var callErr error
+				defer func() { endCall(callErr) }()
 				addrChan := r.Router.FindProvidersAsync(ctx, cid, count)
 				for {
 					select {
@@ -68,16 +188,17 @@ func (r *ComposableParallel) FindProvidersAsync(ctx context.Context, cid cid.Cid
 							return
 						}
 
-						if atomic.AddInt64(&totalCount, 1) > int64(count) && count != 0 {
-							return
+						merged, emit := dedup.merge(addr)
+						if !emit {
+							continue
 						}
 
 						select {
 						case <-ctx.Done():
 							return
-						case addrChanOut <- addr:
+						case addrChanOut <- merged:
+							contributed++
 						}
-
 					}
 				}
 			}
@@ -87,14 +208,80 @@ func (r *ComposableParallel) FindProvidersAsync(ctx context.Context, cid cid.Cid
 	go func() {
 		wg.Wait()
 		close(addrChanOut)
+		endSpan()
 	}()
 
 	return addrChanOut
 }
 
+// dedupedProviders deduplicates peer.AddrInfo results by peer.ID, merging newly seen Addrs into the
+// already emitted AddrInfo for that peer, and reserves a slot out of limit for every newly seen peer.
+// The "is this peer new" decision and the slot reservation happen atomically under mu, so concurrent
+// merge calls for the same peer.ID can never both reserve a slot for it or disagree about whether it
+// was already seen.
+type dedupedProviders struct {
+	mu       sync.Mutex
+	seen     map[peer.ID]peer.AddrInfo
+	limit    int // 0 means unlimited.
+	reserved int
+}
+
+// newDedupedProviders creates a dedupedProviders that will reserve at most limit slots for distinct
+// peer.IDs. A limit of 0 means unlimited.
+func newDedupedProviders(limit int) *dedupedProviders {
+	return &dedupedProviders{
+		seen:  make(map[peer.ID]peer.AddrInfo),
+		limit: limit,
+	}
+}
+
+// merge records addr, merging it with any previously seen AddrInfo for the same peer.ID. It returns the
+// AddrInfo to emit and whether it should be emitted at all: false when addr is a brand-new peer but
+// limit has already been reached, or when addr brought no new addrs for an already seen peer.
+func (d *dedupedProviders) merge(addr peer.AddrInfo) (merged peer.AddrInfo, emit bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	existing, ok := d.seen[addr.ID]
+	if !ok {
+		if d.limit != 0 && d.reserved >= d.limit {
+			return peer.AddrInfo{}, false
+		}
+		d.reserved++
+		d.seen[addr.ID] = addr
+		return addr, true
+	}
+
+	newAddrs := false
+	combined := append([]multiaddr.Multiaddr(nil), existing.Addrs...)
+	for _, a := range addr.Addrs {
+		found := false
+		for _, e := range existing.Addrs {
+			if a.Equal(e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			combined = append(combined, a)
+			newAddrs = true
+		}
+	}
+
+	if !newAddrs {
+		return peer.AddrInfo{}, false
+	}
+
+	existing.Addrs = combined
+	d.seen[addr.ID] = existing
+	return existing, true
+}
+
 // FindPeer will execute all Routers in parallel, getting the first AddrInfo found and cancelling all other Router calls.
 func (r *ComposableParallel) FindPeer(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
-	return getValueOrError(ctx, r.routers,
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableParallel.FindPeer")
+	defer end()
+	return getValueOrError(ctx, "FindPeer", r.ins, r.routers,
 		func(ctx context.Context, r routing.Routing) (peer.AddrInfo, error) {
 			return r.FindPeer(ctx, id)
 		},
@@ -106,7 +293,9 @@ func (r *ComposableParallel) FindPeer(ctx context.Context, id peer.ID) (peer.Add
 // PutValue will execute all Routers in parallel. If a Router fails and IgnoreError flag is not set, the whole execution will fail.
 // Some Puts before the failure might be successful, even if we return an error.
 func (r *ComposableParallel) PutValue(ctx context.Context, key string, val []byte, opts ...routing.Option) error {
-	return execute(ctx, r.routers,
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableParallel.PutValue")
+	defer end()
+	return execute(ctx, "PutValue", r.ins, r.routers,
 		func(ctx context.Context, r routing.Routing) error {
 			return r.PutValue(ctx, key, val, opts...)
 		},
@@ -115,7 +304,9 @@ func (r *ComposableParallel) PutValue(ctx context.Context, key string, val []byt
 
 // GetValue will execute all Routers in parallel. The first value found will be returned, cancelling all other executions.
 func (r *ComposableParallel) GetValue(ctx context.Context, key string, opts ...routing.Option) ([]byte, error) {
-	return getValueOrError(ctx, r.routers,
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableParallel.GetValue")
+	defer end()
+	return getValueOrError(ctx, "GetValue", r.ins, r.routers,
 		func(ctx context.Context, r routing.Routing) ([]byte, error) {
 			return r.GetValue(ctx, key, opts...)
 		},
@@ -125,6 +316,10 @@ func (r *ComposableParallel) GetValue(ctx context.Context, key string, opts ...r
 }
 
 func (r *ComposableParallel) SearchValue(ctx context.Context, key string, opts ...routing.Option) (<-chan []byte, error) {
+	const method = "SearchValue"
+	ins := r.ins
+	ctx, endSpan := ins.tracer.StartSpan(ctx, "ComposableParallel."+method)
+
 	outCh := make(chan []byte)
 	errCh := make(chan error)
 	var wg sync.WaitGroup
@@ -133,22 +328,32 @@ func (r *ComposableParallel) SearchValue(ctx context.Context, key string, opts .
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			waitStart := time.Now()
 			tim := time.NewTimer(r.ExecuteAfter)
 			defer tim.Stop()
 			select {
 			case <-ctx.Done():
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), false)
 				return
 			case <-tim.C:
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), true)
 				ctx, cancel := context.WithTimeout(ctx, r.Timeout)
 				defer cancel()
+				ctx, endCall := ins.startCall(ctx, method, r.Label)
 				valueChan, err := r.Router.SearchValue(ctx, key, opts...)
 				if err != nil && !r.IgnoreError {
+					endCall(err)
 					select {
 					case <-ctx.Done():
 					case errCh <- err:
 					}
 					return
 				}
+				contributed := 0
+				defer func() {
+					ins.observeContribution(method, r.Label, contributed)
+					endCall(nil)
+				}()
 				for {
 					select {
 					case <-ctx.Done():
@@ -161,6 +366,7 @@ func (r *ComposableParallel) SearchValue(ctx context.Context, key string, opts .
 						case <-ctx.Done():
 							return
 						case outCh <- val:
+							contributed++
 						}
 					}
 				}
@@ -173,6 +379,7 @@ func (r *ComposableParallel) SearchValue(ctx context.Context, key string, opts .
 		wg.Wait()
 		close(outCh)
 		close(errCh)
+		endSpan()
 	}()
 
 	select {
@@ -189,7 +396,9 @@ func (r *ComposableParallel) SearchValue(ctx context.Context, key string, opts .
 }
 
 func (r *ComposableParallel) Bootstrap(ctx context.Context) error {
-	return execute(ctx, r.routers,
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableParallel.Bootstrap")
+	defer end()
+	return execute(ctx, "Bootstrap", r.ins, r.routers,
 		func(ctx context.Context, r routing.Routing) error {
 			return r.Bootstrap(ctx)
 		})
@@ -197,6 +406,8 @@ func (r *ComposableParallel) Bootstrap(ctx context.Context) error {
 
 func getValueOrError[T any](
 	ctx context.Context,
+	method string,
+	ins instrumentation,
 	routers []*ParallelRouter,
 	f func(context.Context, routing.Routing) (T, error),
 	isEmpty func(T) bool,
@@ -211,17 +422,22 @@ func getValueOrError[T any](
 		wg.Add(1)
 		go func(r *ParallelRouter) {
 			defer wg.Done()
+			waitStart := time.Now()
 			tim := time.NewTimer(r.ExecuteAfter)
 			defer tim.Stop()
 			select {
 			case <-ctx.Done():
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), false)
 				if !r.IgnoreError {
 					errCh <- ctx.Err()
 				}
 			case <-tim.C:
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), true)
 				ctx, cancel := context.WithTimeout(ctx, r.Timeout)
 				defer cancel()
+				ctx, endCall := ins.startCall(ctx, method, r.Label)
 				value, err := f(ctx, r.Router)
+				endCall(err)
 				if err != nil &&
 					!errors.Is(err, routing.ErrNotFound) &&
 					!r.IgnoreError {
@@ -234,6 +450,7 @@ func getValueOrError[T any](
 				if isEmpty(value) {
 					return
 				}
+				ins.observeContribution(method, r.Label, 1)
 				select {
 				case <-ctx.Done():
 					return
@@ -271,6 +488,8 @@ func getValueOrError[T any](
 
 func execute(
 	ctx context.Context,
+	method string,
+	ins instrumentation,
 	routers []*ParallelRouter,
 	f func(context.Context, routing.Routing,
 	) error) error {
@@ -280,17 +499,22 @@ func execute(
 		wg.Add(1)
 		go func(r *ParallelRouter) {
 			defer wg.Done()
+			waitStart := time.Now()
 			tim := time.NewTimer(r.ExecuteAfter)
 			defer tim.Stop()
 			select {
 			case <-ctx.Done():
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), false)
 				if !r.IgnoreError {
 					errCh <- ctx.Err()
 				}
 			case <-tim.C:
+				ins.observeExecuteAfter(method, r.Label, time.Since(waitStart), true)
 				ctx, cancel := context.WithTimeout(ctx, r.Timeout)
 				defer cancel()
+				ctx, endCall := ins.startCall(ctx, method, r.Label)
 				err := f(ctx, r.Router)
+				endCall(err)
 				if err != nil &&
 					!r.IgnoreError {
 					errCh <- err
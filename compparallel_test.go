@@ -0,0 +1,183 @@
+package routinghelpers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/routing"
+	"github.com/multiformats/go-multiaddr"
+)
+
+// mockProvidersRouter is a minimal routing.Routing whose only meaningful behaviour is
+// FindProvidersAsync, returning a fixed, static set of providers. provideCalls counts calls to
+// Provide, for tests exercising fallback/fan-out paths built on top of it.
+type mockProvidersRouter struct {
+	providers    []peer.AddrInfo
+	provideCalls int32
+}
+
+func (m *mockProvidersRouter) Provide(context.Context, cid.Cid, bool) error {
+	atomic.AddInt32(&m.provideCalls, 1)
+	return nil
+}
+
+func (m *mockProvidersRouter) FindProvidersAsync(ctx context.Context, _ cid.Cid, count int) <-chan peer.AddrInfo {
+	ch := make(chan peer.AddrInfo)
+	go func() {
+		defer close(ch)
+		for i, ai := range m.providers {
+			if count != 0 && i >= count {
+				return
+			}
+			select {
+			case ch <- ai:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func (m *mockProvidersRouter) FindPeer(context.Context, peer.ID) (peer.AddrInfo, error) {
+	return peer.AddrInfo{}, routing.ErrNotFound
+}
+func (m *mockProvidersRouter) PutValue(context.Context, string, []byte, ...routing.Option) error {
+	return nil
+}
+func (m *mockProvidersRouter) GetValue(context.Context, string, ...routing.Option) ([]byte, error) {
+	return nil, routing.ErrNotFound
+}
+func (m *mockProvidersRouter) SearchValue(context.Context, string, ...routing.Option) (<-chan []byte, error) {
+	ch := make(chan []byte)
+	close(ch)
+	return ch, nil
+}
+func (m *mockProvidersRouter) Bootstrap(context.Context) error { return nil }
+
+var _ routing.Routing = &mockProvidersRouter{}
+
+func mustAddr(t *testing.T, s string) multiaddr.Multiaddr {
+	t.Helper()
+	a, err := multiaddr.NewMultiaddr(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a
+}
+
+func TestComposableParallelFindProvidersAsyncDedupAndCount(t *testing.T) {
+	p1 := peer.ID("peer1")
+	p2 := peer.ID("peer2")
+	p3 := peer.ID("peer3")
+
+	router1 := &mockProvidersRouter{providers: []peer.AddrInfo{
+		{ID: p1, Addrs: []multiaddr.Multiaddr{mustAddr(t, "/ip4/127.0.0.1/tcp/4001")}},
+		{ID: p2, Addrs: []multiaddr.Multiaddr{mustAddr(t, "/ip4/127.0.0.1/tcp/4002")}},
+	}}
+	router2 := &mockProvidersRouter{providers: []peer.AddrInfo{
+		{ID: p1, Addrs: []multiaddr.Multiaddr{mustAddr(t, "/ip4/127.0.0.1/tcp/4011")}},
+		{ID: p3, Addrs: []multiaddr.Multiaddr{mustAddr(t, "/ip4/127.0.0.1/tcp/4003")}},
+	}}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: router1, Timeout: time.Second},
+		{Router: router2, Timeout: time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const count = 3
+	seen := make(map[peer.ID]peer.AddrInfo)
+	for ai := range router.FindProvidersAsync(ctx, cid.Undef, count) {
+		// Emissions from different routers can arrive in any order, so keep the one carrying the most addrs.
+		if len(ai.Addrs) >= len(seen[ai.ID].Addrs) {
+			seen[ai.ID] = ai
+		}
+	}
+
+	if len(seen) > count {
+		t.Fatalf("expected at most %d unique peers, got %d", count, len(seen))
+	}
+
+	if len(seen) != 3 {
+		t.Fatalf("expected 3 unique peers, got %d: %v", len(seen), seen)
+	}
+
+	if addrs := seen[p1].Addrs; len(addrs) != 2 {
+		t.Fatalf("expected peer1's addrs to be merged from both routers, got %v", addrs)
+	}
+}
+
+func TestComposableParallelFindProvidersAsyncEnforcesCountBelowAvailable(t *testing.T) {
+	p1 := peer.ID("peer1")
+	p2 := peer.ID("peer2")
+	p3 := peer.ID("peer3")
+
+	router1 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p1}, {ID: p2}}}
+	router2 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p3}}}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: router1, Timeout: time.Second},
+		{Router: router2, Timeout: time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	const count = 2
+	seen := make(map[peer.ID]struct{})
+	for ai := range router.FindProvidersAsync(ctx, cid.Undef, count) {
+		seen[ai.ID] = struct{}{}
+	}
+
+	if len(seen) != count {
+		t.Fatalf("expected exactly %d unique peers out of 3 available, got %d: %v", count, len(seen), seen)
+	}
+}
+
+// TestComposableParallelFindProvidersAsyncOverlapBelowCount combines an overlapping peer ID across
+// routers with a count below the total amount of unique peers available: the condition under which the
+// dedup-then-reserve-slot decision must be atomic, or the channel can both emit a peer twice and exceed
+// count. Repeated across many iterations to shake out goroutine scheduling races.
+func TestComposableParallelFindProvidersAsyncOverlapBelowCount(t *testing.T) {
+	p1 := peer.ID("peer1")
+	overlap := peer.ID("overlap")
+	p2 := peer.ID("peer2")
+
+	const count = 2
+	for i := 0; i < 200; i++ {
+		router1 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p1}, {ID: overlap}}}
+		router2 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: overlap}, {ID: p2}}}
+
+		router := NewComposableParallel([]*ParallelRouter{
+			{Router: router1, Timeout: time.Second},
+			{Router: router2, Timeout: time.Second},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+
+		emitted := make(map[peer.ID]int)
+		total := 0
+		for ai := range router.FindProvidersAsync(ctx, cid.Undef, count) {
+			emitted[ai.ID]++
+			total++
+		}
+		cancel()
+
+		if total > count {
+			t.Fatalf("iteration %d: expected at most %d emissions, got %d: %v", i, count, total, emitted)
+		}
+
+		for id, n := range emitted {
+			if n > 1 {
+				t.Fatalf("iteration %d: peer %v was emitted %d times, expected at most once", i, id, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package routinghelpers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/routing"
+	"github.com/multiformats/go-multihash"
+)
+
+var _ routing.Routing = &ComposableSequential{}
+
+type ComposableSequential struct {
+	routers []*SequentialRouter
+	ins     instrumentation
+}
+
+// NewComposableSequential creates a Router that will execute methods from provided Routers in order.
+// On all methods, If IgnoreError flag is set, that Router will not stop the entire execution.
+func NewComposableSequential(routers []*SequentialRouter) *ComposableSequential {
+	return &ComposableSequential{
+		routers: routers,
+		ins:     newInstrumentation(nil, nil),
+	}
+}
+
+// WithTracer sets the Tracer used to emit spans for every composed call. Passing nil restores the
+// no-op default.
+func (r *ComposableSequential) WithTracer(tracer Tracer) *ComposableSequential {
+	r.ins = newInstrumentation(tracer, r.ins.reporter)
+	return r
+}
+
+// WithMetricsReporter sets the MetricsReporter used to emit latency/result/contribution metrics for
+// every composed call. Passing nil restores the no-op default.
+func (r *ComposableSequential) WithMetricsReporter(reporter MetricsReporter) *ComposableSequential {
+	r.ins = newInstrumentation(r.ins.tracer, reporter)
+	return r
+}
+
+// Provide will call all Routers in order, stopping on the first error (unless IgnoreError is set).
+func (r *ComposableSequential) Provide(ctx context.Context, cid cid.Cid, provide bool) error {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableSequential.Provide")
+	defer end()
+	return executeSequential(ctx, "Provide", r.ins, r.routers,
+		func(ctx context.Context, r routing.Routing) error {
+			return r.Provide(ctx, cid, provide)
+		},
+	)
+}
+
+// ProvideMany will call ProvideMany on every Router, in order, that implements ProvideManyRouter and is
+// Ready. Routers that don't implement ProvideManyRouter (or aren't Ready) will instead have Provide
+// called once per key, fanned out across a bounded worker pool.
+func (r *ComposableSequential) ProvideMany(ctx context.Context, keys []multihash.Multihash) error {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableSequential.ProvideMany")
+	defer end()
+	return executeSequential(ctx, "ProvideMany", r.ins, r.routers,
+		func(ctx context.Context, router routing.Routing) error {
+			if pm, ok := router.(ProvideManyRouter); ok && pm.Ready() {
+				return pm.ProvideMany(ctx, keys)
+			}
+
+			return provideManyFallback(ctx, router, keys)
+		},
+	)
+}
+
+// Ready will report true only if every child Router implementing ProvideManyRouter is Ready. Routers
+// that don't implement ProvideManyRouter are not taken into account, as they don't need to warm up.
+func (r *ComposableSequential) Ready() bool {
+	for _, router := range r.routers {
+		pm, ok := router.Router.(ProvideManyRouter)
+		if !ok {
+			continue
+		}
+
+		if !pm.Ready() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FindProvidersAsync will execute all Routers in order, stopping as soon as count results have
+// been streamed. If count is 0, every Router is drained in turn.
+func (r *ComposableSequential) FindProvidersAsync(ctx context.Context, cid cid.Cid, count int) <-chan peer.AddrInfo {
+	const method = "FindProvidersAsync"
+	ins := r.ins
+	ctx, endSpan := ins.tracer.StartSpan(ctx, "ComposableSequential."+method)
+
+	outCh := make(chan peer.AddrInfo)
+
+	go func() {
+		defer close(outCh)
+		defer endSpan()
+
+		sent := 0
+		for _, router := range r.routers {
+			if ctx.Err() != nil {
+				return
+			}
+
+			nextCount := count
+			if count != 0 {
+				nextCount = count - sent
+			}
+
+			rctx, cancel := context.WithTimeout(ctx, router.Timeout)
+			rctx, endCall := ins.startCall(rctx, method, router.Label)
+			providersChan := router.Router.FindProvidersAsync(rctx, cid, nextCount)
+
+			contributed := 0
+		router:
+			for {
+				select {
+				case <-rctx.Done():
+					break router
+				case addr, ok := <-providersChan:
+					if !ok {
+						break router
+					}
+					select {
+					case <-ctx.Done():
+						ins.observeContribution(method, router.Label, contributed)
+						endCall(ctx.Err())
+						cancel()
+						return
+					case outCh <- addr:
+						sent++
+						contributed++
+						if count != 0 && sent >= count {
+							ins.observeContribution(method, router.Label, contributed)
+							endCall(nil)
+							cancel()
+							return
+						}
+					}
+				}
+			}
+			ins.observeContribution(method, router.Label, contributed)
+			endCall(nil)
+			cancel()
+		}
+	}()
+
+	return outCh
+}
+
+// FindPeer will execute all Routers in order, returning the first result found, skipping
+// routing.ErrNotFound errors from previous Routers.
+func (r *ComposableSequential) FindPeer(ctx context.Context, id peer.ID) (peer.AddrInfo, error) {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableSequential.FindPeer")
+	defer end()
+	return getValueOrErrorSequential(ctx, "FindPeer", r.ins, r.routers,
+		func(ctx context.Context, r routing.Routing) (peer.AddrInfo, error) {
+			return r.FindPeer(ctx, id)
+		},
+		func(ai peer.AddrInfo) bool {
+			return ai.ID == ""
+		})
+}
+
+// PutValue will execute all Routers in order, stopping on the first error (unless IgnoreError is set).
+// Some Puts before the failure might be successful, even if we return an error.
+func (r *ComposableSequential) PutValue(ctx context.Context, key string, val []byte, opts ...routing.Option) error {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableSequential.PutValue")
+	defer end()
+	return executeSequential(ctx, "PutValue", r.ins, r.routers,
+		func(ctx context.Context, r routing.Routing) error {
+			return r.PutValue(ctx, key, val, opts...)
+		},
+	)
+}
+
+// GetValue will execute all Routers in order, returning the first value found, skipping
+// routing.ErrNotFound errors from previous Routers.
+func (r *ComposableSequential) GetValue(ctx context.Context, key string, opts ...routing.Option) ([]byte, error) {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableSequential.GetValue")
+	defer end()
+	return getValueOrErrorSequential(ctx, "GetValue", r.ins, r.routers,
+		func(ctx context.Context, r routing.Routing) ([]byte, error) {
+			return r.GetValue(ctx, key, opts...)
+		},
+		func(ai []byte) bool {
+			return len(ai) == 0
+		})
+}
+
+// SearchValue will execute all Routers in order, streaming values from each one sequentially.
+func (r *ComposableSequential) SearchValue(ctx context.Context, key string, opts ...routing.Option) (<-chan []byte, error) {
+	const method = "SearchValue"
+	ins := r.ins
+	ctx, endSpan := ins.tracer.StartSpan(ctx, "ComposableSequential."+method)
+
+	outCh := make(chan []byte)
+
+	go func() {
+		defer close(outCh)
+		defer endSpan()
+
+		for _, router := range r.routers {
+			if ctx.Err() != nil {
+				return
+			}
+
+			rctx, cancel := context.WithTimeout(ctx, router.Timeout)
+			rctx, endCall := ins.startCall(rctx, method, router.Label)
+			valueChan, err := router.Router.SearchValue(rctx, key, opts...)
+			if err != nil {
+				endCall(err)
+				cancel()
+				if !router.IgnoreError {
+					return
+				}
+				continue
+			}
+
+			contributed := 0
+		router:
+			for {
+				select {
+				case <-rctx.Done():
+					break router
+				case val, ok := <-valueChan:
+					if !ok {
+						break router
+					}
+					select {
+					case <-ctx.Done():
+						ins.observeContribution(method, router.Label, contributed)
+						endCall(ctx.Err())
+						cancel()
+						return
+					case outCh <- val:
+						contributed++
+					}
+				}
+			}
+			ins.observeContribution(method, router.Label, contributed)
+			endCall(nil)
+			cancel()
+		}
+	}()
+
+	return outCh, nil
+}
+
+// Bootstrap will execute all Routers in order, stopping on the first error (unless IgnoreError is set).
+func (r *ComposableSequential) Bootstrap(ctx context.Context) error {
+	ctx, end := r.ins.tracer.StartSpan(ctx, "ComposableSequential.Bootstrap")
+	defer end()
+	return executeSequential(ctx, "Bootstrap", r.ins, r.routers,
+		func(ctx context.Context, r routing.Routing) error {
+			return r.Bootstrap(ctx)
+		})
+}
+
+func getValueOrErrorSequential[T any](
+	ctx context.Context,
+	method string,
+	ins instrumentation,
+	routers []*SequentialRouter,
+	f func(context.Context, routing.Routing) (T, error),
+	isEmpty func(T) bool,
+) (value T, err error) {
+	for _, router := range routers {
+		if ctx.Err() != nil {
+			return value, ctx.Err()
+		}
+
+		rctx, cancel := context.WithTimeout(ctx, router.Timeout)
+		rctx, endCall := ins.startCall(rctx, method, router.Label)
+		value, err = f(rctx, router.Router)
+		endCall(err)
+		cancel()
+
+		if err != nil {
+			if errors.Is(err, routing.ErrNotFound) || router.IgnoreError {
+				continue
+			}
+			return value, err
+		}
+
+		if isEmpty(value) {
+			continue
+		}
+
+		ins.observeContribution(method, router.Label, 1)
+		return value, nil
+	}
+
+	return value, routing.ErrNotFound
+}
+
+func executeSequential(
+	ctx context.Context,
+	method string,
+	ins instrumentation,
+	routers []*SequentialRouter,
+	f func(context.Context, routing.Routing) error,
+) error {
+	for _, router := range routers {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		rctx, cancel := context.WithTimeout(ctx, router.Timeout)
+		rctx, endCall := ins.startCall(rctx, method, router.Label)
+		err := f(rctx, router.Router)
+		endCall(err)
+		cancel()
+
+		if err != nil && !router.IgnoreError {
+			return err
+		}
+	}
+
+	return nil
+}
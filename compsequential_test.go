@@ -0,0 +1,65 @@
+package routinghelpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestComposableSequentialFindProvidersAsyncUnlimitedCount(t *testing.T) {
+	p1 := peer.ID("peer1")
+	p2 := peer.ID("peer2")
+	p3 := peer.ID("peer3")
+
+	router1 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p1}, {ID: p2}}}
+	router2 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p3}}}
+
+	router := NewComposableSequential([]*SequentialRouter{
+		{Router: router1, Timeout: time.Second},
+		{Router: router2, Timeout: time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []peer.ID
+	for ai := range router.FindProvidersAsync(ctx, cid.Undef, 0) {
+		got = append(got, ai.ID)
+	}
+
+	if len(got) != 3 {
+		t.Fatalf("expected providers from every router with an unlimited count, got %v", got)
+	}
+}
+
+func TestComposableSequentialFindProvidersAsyncCount(t *testing.T) {
+	p1 := peer.ID("peer1")
+	p2 := peer.ID("peer2")
+	p3 := peer.ID("peer3")
+
+	router1 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p1}, {ID: p2}}}
+	router2 := &mockProvidersRouter{providers: []peer.AddrInfo{{ID: p3}}}
+
+	router := NewComposableSequential([]*SequentialRouter{
+		{Router: router1, Timeout: time.Second},
+		{Router: router2, Timeout: time.Second},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []peer.ID
+	for ai := range router.FindProvidersAsync(ctx, cid.Undef, 1) {
+		got = append(got, ai.ID)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("expected exactly 1 provider when count=1, got %v", got)
+	}
+	if got[0] != p1 {
+		t.Fatalf("expected the first router's first provider, got %v", got[0])
+	}
+}
@@ -0,0 +1,94 @@
+package routinghelpers
+
+import (
+	"context"
+	"time"
+)
+
+// MetricsReporter receives instrumentation events from composed Routers. Implementations are expected
+// to forward these into whatever metrics backend the embedder uses (OpenCensus, OpenTelemetry,
+// Prometheus...). All methods must be safe for concurrent use.
+type MetricsReporter interface {
+	// ObserveLatency records how long a single child Router call took for a given outer method.
+	ObserveLatency(method, routerLabel string, latency time.Duration)
+	// ObserveResult records whether a child Router call for a given outer method succeeded.
+	ObserveResult(method, routerLabel string, err error)
+	// ObserveExecuteAfter records how long a Router actually waited on its ExecuteAfter delay before
+	// being cancelled (elapsed=false) or released to run (elapsed=true).
+	ObserveExecuteAfter(method, routerLabel string, waited time.Duration, elapsed bool)
+	// ObserveContribution records how many providers/values a Router contributed to a race: e.g. the
+	// amount of provider results it emitted through FindProvidersAsync (an updated AddrInfo carrying
+	// merged addrs for an already emitted peer counts too), or 1 for a Router that found a value for
+	// GetValue/FindPeer. Routers that found no value for GetValue/FindPeer report nothing.
+	ObserveContribution(method, routerLabel string, count int)
+}
+
+// Tracer creates spans around outer composed calls and per-router child calls, so that in a stack like
+// [DHT, HTTP-delegate, Reframe] an operator can see which Router actually won a call.
+type Tracer interface {
+	// StartSpan starts a span for an outer call (e.g. "ComposableParallel.GetValue") and returns a context carrying it
+	// along with a function to end it.
+	StartSpan(ctx context.Context, method string) (context.Context, func())
+	// StartChildSpan starts a span for a single child Router call within an outer call's span.
+	StartChildSpan(ctx context.Context, method, routerLabel string) (context.Context, func())
+}
+
+// noopMetricsReporter is the default MetricsReporter, used when none is configured so that existing
+// embedders are unaffected.
+type noopMetricsReporter struct{}
+
+func (noopMetricsReporter) ObserveLatency(string, string, time.Duration)           {}
+func (noopMetricsReporter) ObserveResult(string, string, error)                    {}
+func (noopMetricsReporter) ObserveExecuteAfter(string, string, time.Duration, bool) {}
+func (noopMetricsReporter) ObserveContribution(string, string, int)                {}
+
+// noopTracer is the default Tracer, used when none is configured so that existing embedders are
+// unaffected.
+type noopTracer struct{}
+
+func (noopTracer) StartSpan(ctx context.Context, _ string) (context.Context, func()) {
+	return ctx, func() {}
+}
+
+func (noopTracer) StartChildSpan(ctx context.Context, _, _ string) (context.Context, func()) {
+	return ctx, func() {}
+}
+
+// instrumentation bundles a Tracer and MetricsReporter, defaulting both to no-ops, so call sites don't
+// need nil checks.
+type instrumentation struct {
+	tracer   Tracer
+	reporter MetricsReporter
+}
+
+func newInstrumentation(tracer Tracer, reporter MetricsReporter) instrumentation {
+	if tracer == nil {
+		tracer = noopTracer{}
+	}
+	if reporter == nil {
+		reporter = noopMetricsReporter{}
+	}
+	return instrumentation{tracer: tracer, reporter: reporter}
+}
+
+// startCall starts a child span for a single router call and returns a context to run it with and a
+// function to call with its outcome, recording latency and result.
+func (ins instrumentation) startCall(ctx context.Context, method, routerLabel string) (context.Context, func(err error)) {
+	ctx, endSpan := ins.tracer.StartChildSpan(ctx, method, routerLabel)
+	start := time.Now()
+	return ctx, func(err error) {
+		ins.reporter.ObserveLatency(method, routerLabel, time.Since(start))
+		ins.reporter.ObserveResult(method, routerLabel, err)
+		endSpan()
+	}
+}
+
+// observeExecuteAfter reports how long a Router waited on its ExecuteAfter delay.
+func (ins instrumentation) observeExecuteAfter(method, routerLabel string, waited time.Duration, elapsed bool) {
+	ins.reporter.ObserveExecuteAfter(method, routerLabel, waited, elapsed)
+}
+
+// observeContribution reports how many providers/values a Router contributed to a race.
+func (ins instrumentation) observeContribution(method, routerLabel string, count int) {
+	ins.reporter.ObserveContribution(method, routerLabel, count)
+}
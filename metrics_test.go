@@ -0,0 +1,146 @@
+package routinghelpers
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p-core/routing"
+)
+
+// mockValueRouter embeds mockProvidersRouter and returns a fixed value from GetValue.
+type mockValueRouter struct {
+	mockProvidersRouter
+	value []byte
+}
+
+func (m *mockValueRouter) GetValue(context.Context, string, ...routing.Option) ([]byte, error) {
+	return m.value, nil
+}
+
+// recordingReporter is a MetricsReporter that records every observation for assertions.
+type recordingReporter struct {
+	mu            sync.Mutex
+	latencies     []string
+	results       []string
+	executeAfters []string
+	contributions []string
+}
+
+func (r *recordingReporter) ObserveLatency(method, label string, _ time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies = append(r.latencies, method+"/"+label)
+}
+
+func (r *recordingReporter) ObserveResult(method, label string, _ error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.results = append(r.results, method+"/"+label)
+}
+
+func (r *recordingReporter) ObserveExecuteAfter(method, label string, _ time.Duration, elapsed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mark := "cancelled"
+	if elapsed {
+		mark = "elapsed"
+	}
+	r.executeAfters = append(r.executeAfters, method+"/"+label+"/"+mark)
+}
+
+func (r *recordingReporter) ObserveContribution(method, label string, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if count > 0 {
+		r.contributions = append(r.contributions, method+"/"+label)
+	}
+}
+
+func (r *recordingReporter) has(list []string, want string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, v := range list {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestComposableParallelInstrumentsProvide(t *testing.T) {
+	reporter := &recordingReporter{}
+	router1 := &mockProvidersRouter{}
+	router2 := &mockProvidersRouter{}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: router1, Timeout: time.Second, Label: "router1"},
+		{Router: router2, Timeout: time.Second, Label: "router2"},
+	}).WithMetricsReporter(reporter)
+
+	if err := router.Provide(context.Background(), cid.Undef, true); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, label := range []string{"router1", "router2"} {
+		if !reporter.has(reporter.executeAfters, "Provide/"+label+"/elapsed") {
+			t.Fatalf("expected an elapsed ExecuteAfter observation for %s, got %v", label, reporter.executeAfters)
+		}
+		if !reporter.has(reporter.results, "Provide/"+label) {
+			t.Fatalf("expected a result observation for %s, got %v", label, reporter.results)
+		}
+		if !reporter.has(reporter.latencies, "Provide/"+label) {
+			t.Fatalf("expected a latency observation for %s, got %v", label, reporter.latencies)
+		}
+	}
+}
+
+func TestComposableParallelInstrumentsGetValueContribution(t *testing.T) {
+	reporter := &recordingReporter{}
+	router1 := &mockValueRouter{value: []byte("hello")}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: router1, Timeout: time.Second, Label: "router1"},
+	}).WithMetricsReporter(reporter)
+
+	val, err := router.GetValue(context.Background(), "/some/key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(val) != "hello" {
+		t.Fatalf("unexpected value: %s", val)
+	}
+
+	if !reporter.has(reporter.contributions, "GetValue/router1") {
+		t.Fatalf("expected a contribution observation for router1, got %v", reporter.contributions)
+	}
+}
+
+func TestComposableParallelInstrumentsSearchValueExecuteAfter(t *testing.T) {
+	reporter := &recordingReporter{}
+	router1 := &mockProvidersRouter{}
+	router2 := &mockProvidersRouter{}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: router1, Timeout: time.Second, Label: "router1"},
+		{Router: router2, Timeout: time.Second, Label: "router2"},
+	}).WithMetricsReporter(reporter)
+
+	ch, err := router.SearchValue(context.Background(), "/some/key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range ch {
+	}
+
+	for _, label := range []string{"router1", "router2"} {
+		if !reporter.has(reporter.executeAfters, "SearchValue/"+label+"/elapsed") {
+			t.Fatalf("expected an elapsed ExecuteAfter observation for %s, got %v", label, reporter.executeAfters)
+		}
+		if !reporter.has(reporter.results, "SearchValue/"+label) {
+			t.Fatalf("expected a result observation for %s, got %v", label, reporter.results)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package routinghelpers
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/multiformats/go-multihash"
+)
+
+// mockProvideManyRouter wraps mockProvidersRouter and additionally implements ProvideManyRouter.
+type mockProvideManyRouter struct {
+	mockProvidersRouter
+	ready       bool
+	provideMany int32
+}
+
+func (m *mockProvideManyRouter) ProvideMany(context.Context, []multihash.Multihash) error {
+	atomic.AddInt32(&m.provideMany, 1)
+	return nil
+}
+
+func (m *mockProvideManyRouter) Ready() bool { return m.ready }
+
+var _ ProvideManyRouter = &mockProvideManyRouter{}
+
+func testMultihashes(t *testing.T, n int) []multihash.Multihash {
+	t.Helper()
+	out := make([]multihash.Multihash, n)
+	for i := range out {
+		mh, err := multihash.Sum([]byte{byte(i)}, multihash.SHA2_256, -1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out[i] = mh
+	}
+	return out
+}
+
+func TestComposableParallelProvideManyUsesReadyRouter(t *testing.T) {
+	pm := &mockProvideManyRouter{ready: true}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: pm, Timeout: time.Second},
+	})
+
+	keys := testMultihashes(t, 3)
+	if err := router.ProvideMany(context.Background(), keys); err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&pm.provideMany) != 1 {
+		t.Fatalf("expected ProvideMany to be called once on the ready router, got %d calls", pm.provideMany)
+	}
+	if atomic.LoadInt32(&pm.provideCalls) != 0 {
+		t.Fatalf("expected the ready router's ProvideMany path to be used, not the Provide fallback")
+	}
+}
+
+func TestComposableParallelProvideManyFallsBackWhenNotReady(t *testing.T) {
+	pm := &mockProvideManyRouter{ready: false}
+
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: pm, Timeout: time.Second},
+	})
+
+	keys := testMultihashes(t, 5)
+	if err := router.ProvideMany(context.Background(), keys); err != nil {
+		t.Fatal(err)
+	}
+
+	if int(atomic.LoadInt32(&pm.provideCalls)) != len(keys) {
+		t.Fatalf("expected the fallback to call Provide once per key, got %d calls for %d keys", pm.provideCalls, len(keys))
+	}
+	if atomic.LoadInt32(&pm.provideMany) != 0 {
+		t.Fatalf("expected ProvideMany not to be called when the router isn't Ready")
+	}
+}
+
+func TestComposableParallelReadyReflectsChildRouters(t *testing.T) {
+	router := NewComposableParallel([]*ParallelRouter{
+		{Router: &mockProvideManyRouter{ready: true}, Timeout: time.Second},
+		{Router: &mockProvideManyRouter{ready: false}, Timeout: time.Second},
+	})
+
+	if router.Ready() {
+		t.Fatal("expected Ready to be false when one child ProvideManyRouter isn't ready")
+	}
+}
+
+func TestComposableSequentialProvideManyUsesReadyRouter(t *testing.T) {
+	pm := &mockProvideManyRouter{ready: true}
+
+	router := NewComposableSequential([]*SequentialRouter{
+		{Router: pm, Timeout: time.Second},
+	})
+
+	keys := testMultihashes(t, 3)
+	if err := router.ProvideMany(context.Background(), keys); err != nil {
+		t.Fatal(err)
+	}
+
+	if atomic.LoadInt32(&pm.provideMany) != 1 {
+		t.Fatalf("expected ProvideMany to be called once on the ready router, got %d calls", pm.provideMany)
+	}
+}
+
+func TestComposableSequentialProvideManyFallsBackWhenNotReady(t *testing.T) {
+	pm := &mockProvideManyRouter{ready: false}
+
+	router := NewComposableSequential([]*SequentialRouter{
+		{Router: pm, Timeout: time.Second},
+	})
+
+	keys := testMultihashes(t, 4)
+	if err := router.ProvideMany(context.Background(), keys); err != nil {
+		t.Fatal(err)
+	}
+
+	if int(atomic.LoadInt32(&pm.provideCalls)) != len(keys) {
+		t.Fatalf("expected the fallback to call Provide once per key, got %d calls for %d keys", pm.provideCalls, len(keys))
+	}
+}